@@ -3,27 +3,63 @@ package main
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 	"gitlab.com/turn1de/acc_client"
 )
 
 var client acc_client.Client
 
+const (
+	// realtimeUpdateInterval is how often ACC sends the session and car updates
+	realtimeUpdateInterval = 500 * time.Millisecond
+	// publishInterval is how often changed standings are pushed to the frontend and live server
+	publishInterval = 250 * time.Millisecond
+)
+
 // App struct
 type App struct {
-	ctx context.Context
+	ctx       context.Context
+	standings *Standings
+	layouts   *LayoutStore
+	server    *LiveServer
+	stop      chan struct{}
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	return &App{standings: NewStandings()}
 }
 
 // startup is called at application startup
 func (a *App) startup(ctx context.Context) {
 	// Perform your setup here
 	a.ctx = ctx
+
+	path, err := defaultLayoutPath()
+	if err != nil {
+		runtime.LogErrorf(ctx, "overlay layouts will not be persisted: %v", err)
+	}
+	a.layouts = NewLayoutStore(path)
+	if err := a.layouts.Load(); err != nil {
+		runtime.LogErrorf(ctx, "failed to load overlay layouts: %v", err)
+	}
+
+	a.server = NewLiveServer(ServerAddress, func(format string, args ...interface{}) {
+		runtime.LogErrorf(ctx, format, args...)
+	})
+	a.server.HandleGet("/standings", func() interface{} { return a.GetStandings() })
+	a.server.HandleGet("/session", func() interface{} { return a.GetSession() })
+	a.server.HandleGet("/layout", func() interface{} { return a.GetActiveLayout() })
+	a.server.HandleGet("/classDelta", func() interface{} { return a.GetClassDelta() })
+	if err := a.server.Start(); err != nil {
+		runtime.LogErrorf(ctx, "failed to start live server on %s: %v", ServerAddress, err)
+	}
+
+	a.stop = make(chan struct{})
+	go a.publishStandings()
 }
 
 // domReady is called after front-end resources have been loaded
@@ -41,6 +77,16 @@ func (a *App) beforeClose(ctx context.Context) (prevent bool) {
 // shutdown is called at application termination
 func (a *App) shutdown(ctx context.Context) {
 	// Perform your teardown here
+	close(a.stop)
+	if err := a.server.Stop(ctx); err != nil {
+		runtime.LogErrorf(ctx, "failed to stop live server: %v", err)
+	}
+}
+
+// emit sends an update to the frontend and to live server clients subscribed to the topic
+func (a *App) emit(topic string, data interface{}) {
+	runtime.EventsEmit(a.ctx, topic, data)
+	a.server.Publish(topic, data)
 }
 
 // Greet returns a greeting for the given name
@@ -50,5 +96,104 @@ func (a *App) Greet(name string) string {
 
 // Connect to ACC UDP
 func (a *App) Connect(address string, name string, password string, commandPassword string) {
-	client.ConnectAndListen(address, name, password, commandPassword, 5*time.Second, 30*time.Second)
+	a.standings.Reset()
+
+	client.OnEntryListUpdate = a.standings.OnEntryListUpdate
+	client.OnEntryListCarUpdate = a.standings.OnEntryListCarUpdate
+	client.OnRealtimeCarUpdate = a.standings.OnRealtimeCarUpdate
+	client.OnTrackUpdate = a.standings.OnTrackUpdate
+	client.OnRealtimeUpdate = func(update acc_client.RealtimeUpdate) {
+		a.standings.OnRealtimeUpdate(update)
+
+		if key := layoutKey(update.SessionType); a.layouts.Activate(key) {
+			a.emit("layout", a.layouts.Get(key))
+		}
+	}
+
+	client.ConnectAndListen(address, name, password, commandPassword, realtimeUpdateInterval, 30*time.Second)
+}
+
+// publishStandings pushes the standings, session state and class delta whenever they changed.
+// ACC sends the session update before the car updates of the same interval, so
+// nothing in the update stream marks the end of an interval to publish on.
+func (a *App) publishStandings() {
+	ticker := time.NewTicker(publishInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+			if a.standings.TakeChanged() {
+				a.emit("standings", a.standings.Cars())
+				a.emit("session", a.standings.Session())
+				a.emit("classDelta", a.standings.ClassDelta())
+			}
+		}
+	}
+}
+
+// GetStandings returns the current standings of the full field ordered by position
+func (a *App) GetStandings() []CarStanding {
+	return a.standings.Cars()
+}
+
+// GetSession returns the current session state
+func (a *App) GetSession() SessionInfo {
+	return a.standings.Session()
+}
+
+// GetClassTarget returns the configured class reference lap
+func (a *App) GetClassTarget() ClassTarget {
+	return a.standings.ClassTarget()
+}
+
+// SetClassTarget configures the class reference lap the focused car is compared with
+func (a *App) SetClassTarget(target ClassTarget) {
+	a.standings.SetClassTarget(target)
+}
+
+// GetClassDelta returns the focused car's delta to the class reference lap
+func (a *App) GetClassDelta() ClassDelta {
+	return a.standings.ClassDelta()
+}
+
+// GetLayout returns the overlay layout stored for key
+func (a *App) GetLayout(key string) Layout {
+	return a.layouts.Get(key)
+}
+
+// GetActiveLayout returns the overlay layout of the current session type
+func (a *App) GetActiveLayout() Layout {
+	return a.layouts.Get(a.layouts.Active())
+}
+
+// SaveLayout stores the overlay layout for key
+func (a *App) SaveLayout(key string, layout Layout) error {
+	before := a.GetActiveLayout()
+	if err := a.layouts.Set(key, layout); err != nil {
+		return err
+	}
+	a.emitLayoutIfChanged(before)
+	return nil
+}
+
+// DeleteLayout removes the overlay layout stored for key
+func (a *App) DeleteLayout(key string) error {
+	before := a.GetActiveLayout()
+	if err := a.layouts.Delete(key); err != nil {
+		return err
+	}
+	a.emitLayoutIfChanged(before)
+	return nil
+}
+
+// emitLayoutIfChanged sends the active layout when it differs from before. Besides
+// saving the active key itself, deleting it or saving the default it falls back
+// to also change the active layout.
+func (a *App) emitLayoutIfChanged(before Layout) {
+	if after := a.GetActiveLayout(); !reflect.DeepEqual(before, after) {
+		a.emit("layout", after)
+	}
 }
@@ -0,0 +1,67 @@
+package main
+
+// ClassTarget is a reference lap time for the player's class, such as a BoP or league target
+type ClassTarget struct {
+	ReferenceLapMs int `json:"referenceLapMs"`
+	// TolerancePct is how far over the reference, in percent, a best lap may be before the car underperforms
+	TolerancePct float64 `json:"tolerancePct"`
+}
+
+// ClassDelta is the focused car's pace against the class target. Deltas are
+// positive when slower than the reference and only set when the lap exists.
+type ClassDelta struct {
+	CarId           int  `json:"carId"`
+	ReferenceLapMs  int  `json:"referenceLapMs"`
+	HasBestLap      bool `json:"hasBestLap"`
+	BestLapDeltaMs  int  `json:"bestLapDeltaMs"`
+	HasLastLap      bool `json:"hasLastLap"`
+	LastLapDeltaMs  int  `json:"lastLapDeltaMs"`
+	Underperforming bool `json:"underperforming"`
+}
+
+// classDelta compares the laps of car with target
+func classDelta(target ClassTarget, car CarStanding) ClassDelta {
+	d := ClassDelta{CarId: car.CarId, ReferenceLapMs: target.ReferenceLapMs}
+	if target.ReferenceLapMs <= 0 {
+		return d
+	}
+
+	if car.BestLapMs > 0 {
+		d.HasBestLap = true
+		d.BestLapDeltaMs = car.BestLapMs - target.ReferenceLapMs
+		limit := float64(target.ReferenceLapMs) * (1 + target.TolerancePct/100)
+		d.Underperforming = float64(car.BestLapMs) > limit
+	}
+	if car.LastLapMs > 0 {
+		d.HasLastLap = true
+		d.LastLapDeltaMs = car.LastLapMs - target.ReferenceLapMs
+	}
+	return d
+}
+
+// SetClassTarget replaces the class target. It is kept across Reset.
+func (s *Standings) SetClassTarget(target ClassTarget) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.target = target
+	s.changed = true
+}
+
+// ClassTarget returns the class target
+func (s *Standings) ClassTarget() ClassTarget {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.target
+}
+
+// ClassDelta returns the focused car's delta to the class target
+func (s *Standings) ClassDelta() ClassDelta {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	car := CarStanding{CarId: s.session.FocusedCarId, LastLapMs: -1, BestLapMs: -1}
+	if c, ok := s.cars[uint16(s.session.FocusedCarId)]; ok {
+		car = *c
+	}
+	return classDelta(s.target, car)
+}
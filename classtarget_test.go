@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"gitlab.com/turn1de/acc_client"
+)
+
+func TestClassDelta(t *testing.T) {
+	target := ClassTarget{ReferenceLapMs: 100000, TolerancePct: 1}
+
+	tests := []struct {
+		name   string
+		target ClassTarget
+		car    CarStanding
+		want   ClassDelta
+	}{
+		{"no target", ClassTarget{}, CarStanding{CarId: 1, BestLapMs: 101000, LastLapMs: 102000},
+			ClassDelta{CarId: 1}},
+		{"no laps", target, CarStanding{CarId: 1, BestLapMs: -1, LastLapMs: -1},
+			ClassDelta{CarId: 1, ReferenceLapMs: 100000}},
+		{"faster than reference", target, CarStanding{CarId: 1, BestLapMs: 99500, LastLapMs: 100200},
+			ClassDelta{CarId: 1, ReferenceLapMs: 100000, HasBestLap: true, BestLapDeltaMs: -500, HasLastLap: true, LastLapDeltaMs: 200}},
+		{"within tolerance", target, CarStanding{CarId: 1, BestLapMs: 101000, LastLapMs: -1},
+			ClassDelta{CarId: 1, ReferenceLapMs: 100000, HasBestLap: true, BestLapDeltaMs: 1000}},
+		{"underperforming", target, CarStanding{CarId: 1, BestLapMs: 101001, LastLapMs: 103000},
+			ClassDelta{CarId: 1, ReferenceLapMs: 100000, HasBestLap: true, BestLapDeltaMs: 1001, HasLastLap: true, LastLapDeltaMs: 3000, Underperforming: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classDelta(tt.target, tt.car); got != tt.want {
+				t.Errorf("classDelta() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandingsClassDeltaFollowsFocusedCar(t *testing.T) {
+	s := NewStandings()
+	s.SetClassTarget(ClassTarget{ReferenceLapMs: 100000})
+	s.OnRealtimeCarUpdate(acc_client.RealtimeCarUpdate{Id: 1, BestSessionLap: acc_client.Lap{LapTimeMs: 99000}})
+	s.OnRealtimeCarUpdate(acc_client.RealtimeCarUpdate{Id: 2, BestSessionLap: acc_client.Lap{LapTimeMs: 102000}})
+
+	s.OnRealtimeUpdate(acc_client.RealtimeUpdate{FocusedCarIndex: 2})
+	if got := s.ClassDelta(); got.CarId != 2 || got.BestLapDeltaMs != 2000 {
+		t.Errorf("ClassDelta() = %+v, want car 2 at +2000", got)
+	}
+
+	s.Reset()
+	if got := s.ClassTarget().ReferenceLapMs; got != 100000 {
+		t.Errorf("Reset cleared the class target, ReferenceLapMs = %d", got)
+	}
+}
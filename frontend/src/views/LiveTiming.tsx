@@ -1,9 +1,81 @@
 import type { Component } from "solid-js"
-import { createSignal } from "solid-js"
+import { createSignal, For, onCleanup, onMount, Show } from "solid-js"
+import { GetClassDelta, GetStandings } from "../../wailsjs/go/main/App"
+import { EventsOn } from "../../wailsjs/runtime/runtime"
+import { main } from "../../wailsjs/go/models"
+
+const formatLap = (ms: number) => {
+  if (ms < 0) return "-"
+  const minutes = Math.floor(ms / 60000)
+  const seconds = ((ms % 60000) / 1000).toFixed(3).padStart(6, "0")
+  return `${minutes}:${seconds}`
+}
+
+const formatDelta = (ms: number) => `${ms > 0 ? "+" : ms < 0 ? "-" : ""}${(Math.abs(ms) / 1000).toFixed(3)}`
+
+const driverName = (car: main.CarStanding) => {
+  const driver = car.drivers?.[car.currentDriver]
+  return driver ? `${driver.firstName} ${driver.lastName}` : ""
+}
 
 const LiveTiming: Component = () => {
+  const [standings, setStandings] = createSignal<main.CarStanding[]>([])
+  const [classDelta, setClassDelta] = createSignal<main.ClassDelta>()
+
+  onMount(() => {
+    GetStandings().then(setStandings)
+    GetClassDelta().then(setClassDelta)
+    const offStandings = EventsOn("standings", setStandings)
+    const offClassDelta = EventsOn("classDelta", setClassDelta)
+    onCleanup(() => {
+      offStandings()
+      offClassDelta()
+    })
+  })
 
-  return (<div>Live timing </div>)
+  return (
+    <div class="overflow-x-auto">
+      <Show when={classDelta()?.referenceLapMs}>
+        <p class={classDelta()?.underperforming ? "text-error" : ""}>
+          Class target {formatLap(classDelta()!.referenceLapMs)}:
+          best {classDelta()!.hasBestLap ? formatDelta(classDelta()!.bestLapDeltaMs) : "-"},
+          last {classDelta()!.hasLastLap ? formatDelta(classDelta()!.lastLapDeltaMs) : "-"}
+        </p>
+      </Show>
+      <table class="table table-zebra">
+        <thead>
+          <tr>
+            <th>Pos</th>
+            <th>#</th>
+            <th>Driver</th>
+            <th>Team</th>
+            <th>Laps</th>
+            <th>Sectors</th>
+            <th>Last</th>
+            <th>Best</th>
+            <th></th>
+          </tr>
+        </thead>
+        <tbody>
+          <For each={standings()}>
+            {(car) => (
+              <tr>
+                <td>{car.position || "-"}</td>
+                <td>{car.raceNumber}</td>
+                <td>{driverName(car)}</td>
+                <td>{car.teamName}</td>
+                <td>{car.laps}</td>
+                <td>{(car.lastSectorsMs ?? []).map(formatLap).join(" | ")}</td>
+                <td>{formatLap(car.lastLapMs)}</td>
+                <td>{formatLap(car.bestLapMs)}</td>
+                <td>{car.inPit ? "PIT" : ""}</td>
+              </tr>
+            )}
+          </For>
+        </tbody>
+      </table>
+    </div>
+  )
 }
 
 export default LiveTiming
@@ -1,6 +1,25 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
 
 export function Connect(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
 
+export function DeleteLayout(arg1:string):Promise<void>;
+
+export function GetActiveLayout():Promise<main.Layout>;
+
+export function GetClassDelta():Promise<main.ClassDelta>;
+
+export function GetClassTarget():Promise<main.ClassTarget>;
+
+export function GetLayout(arg1:string):Promise<main.Layout>;
+
+export function GetSession():Promise<main.SessionInfo>;
+
+export function GetStandings():Promise<Array<main.CarStanding>>;
+
 export function Greet(arg1:string):Promise<string>;
+
+export function SaveLayout(arg1:string,arg2:main.Layout):Promise<void>;
+
+export function SetClassTarget(arg1:main.ClassTarget):Promise<void>;
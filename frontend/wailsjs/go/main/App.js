@@ -6,6 +6,42 @@ export function Connect(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['Connect'](arg1, arg2, arg3, arg4);
 }
 
+export function DeleteLayout(arg1) {
+  return window['go']['main']['App']['DeleteLayout'](arg1);
+}
+
+export function GetActiveLayout() {
+  return window['go']['main']['App']['GetActiveLayout']();
+}
+
+export function GetClassDelta() {
+  return window['go']['main']['App']['GetClassDelta']();
+}
+
+export function GetClassTarget() {
+  return window['go']['main']['App']['GetClassTarget']();
+}
+
+export function GetLayout(arg1) {
+  return window['go']['main']['App']['GetLayout'](arg1);
+}
+
+export function GetSession() {
+  return window['go']['main']['App']['GetSession']();
+}
+
+export function GetStandings() {
+  return window['go']['main']['App']['GetStandings']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
+
+export function SaveLayout(arg1, arg2) {
+  return window['go']['main']['App']['SaveLayout'](arg1, arg2);
+}
+
+export function SetClassTarget(arg1) {
+  return window['go']['main']['App']['SetClassTarget'](arg1);
+}
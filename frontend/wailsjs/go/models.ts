@@ -0,0 +1,249 @@
+export namespace main {
+	
+	export class Driver {
+	    firstName: string;
+	    lastName: string;
+	    shortName: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Driver(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.firstName = source["firstName"];
+	        this.lastName = source["lastName"];
+	        this.shortName = source["shortName"];
+	    }
+	}
+	export class CarStanding {
+	    carId: number;
+	    raceNumber: number;
+	    teamName: string;
+	    carModel: number;
+	    cupCategory: number;
+	    drivers: Driver[];
+	    currentDriver: number;
+	    position: number;
+	    cupPosition: number;
+	    trackPosition: number;
+	    spline: number;
+	    laps: number;
+	    deltaMs: number;
+	    inPit: boolean;
+	    currentLapMs: number;
+	    lastLapMs: number;
+	    bestLapMs: number;
+	    currentSectorsMs: number[];
+	    lastSectorsMs: number[];
+	    bestSectorsMs: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CarStanding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.carId = source["carId"];
+	        this.raceNumber = source["raceNumber"];
+	        this.teamName = source["teamName"];
+	        this.carModel = source["carModel"];
+	        this.cupCategory = source["cupCategory"];
+	        this.drivers = this.convertValues(source["drivers"], Driver);
+	        this.currentDriver = source["currentDriver"];
+	        this.position = source["position"];
+	        this.cupPosition = source["cupPosition"];
+	        this.trackPosition = source["trackPosition"];
+	        this.spline = source["spline"];
+	        this.laps = source["laps"];
+	        this.deltaMs = source["deltaMs"];
+	        this.inPit = source["inPit"];
+	        this.currentLapMs = source["currentLapMs"];
+	        this.lastLapMs = source["lastLapMs"];
+	        this.bestLapMs = source["bestLapMs"];
+	        this.currentSectorsMs = source["currentSectorsMs"];
+	        this.lastSectorsMs = source["lastSectorsMs"];
+	        this.bestSectorsMs = source["bestSectorsMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ClassDelta {
+	    carId: number;
+	    referenceLapMs: number;
+	    hasBestLap: boolean;
+	    bestLapDeltaMs: number;
+	    hasLastLap: boolean;
+	    lastLapDeltaMs: number;
+	    underperforming: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClassDelta(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.carId = source["carId"];
+	        this.referenceLapMs = source["referenceLapMs"];
+	        this.hasBestLap = source["hasBestLap"];
+	        this.bestLapDeltaMs = source["bestLapDeltaMs"];
+	        this.hasLastLap = source["hasLastLap"];
+	        this.lastLapDeltaMs = source["lastLapDeltaMs"];
+	        this.underperforming = source["underperforming"];
+	    }
+	}
+	export class ClassTarget {
+	    referenceLapMs: number;
+	    tolerancePct: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClassTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.referenceLapMs = source["referenceLapMs"];
+	        this.tolerancePct = source["tolerancePct"];
+	    }
+	}
+	
+	export class WidgetLayout {
+	    id: string;
+	    monitor: number;
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	    visible: boolean;
+	    subscriptions: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WidgetLayout(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.monitor = source["monitor"];
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.visible = source["visible"];
+	        this.subscriptions = source["subscriptions"];
+	    }
+	}
+	export class Layout {
+	    name: string;
+	    widgets: WidgetLayout[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Layout(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.widgets = this.convertValues(source["widgets"], WidgetLayout);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WeatherData {
+	    ambientTemp: number;
+	    trackTemp: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeatherData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ambientTemp = source["ambientTemp"];
+	        this.trackTemp = source["trackTemp"];
+	    }
+	}
+	export class SessionInfo {
+	    sessionIndex: number;
+	    trackName: string;
+	    trackLength: number;
+	    sessionType: number;
+	    phase: number;
+	    sessionTimeMs: number;
+	    sessionEndTimeMs: number;
+	    focusedCarId: number;
+	    bestLapMs: number;
+	    weather: WeatherData;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionIndex = source["sessionIndex"];
+	        this.trackName = source["trackName"];
+	        this.trackLength = source["trackLength"];
+	        this.sessionType = source["sessionType"];
+	        this.phase = source["phase"];
+	        this.sessionTimeMs = source["sessionTimeMs"];
+	        this.sessionEndTimeMs = source["sessionEndTimeMs"];
+	        this.focusedCarId = source["focusedCarId"];
+	        this.bestLapMs = source["bestLapMs"];
+	        this.weather = this.convertValues(source["weather"], WeatherData);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+
+}
+
@@ -7,6 +7,7 @@ toolchain go1.21.3
 require (
 	github.com/wailsapp/wails/v2 v2.8.0
 	gitlab.com/turn1de/acc_client v0.0.0-20220312090612-648bd6670fbb
+	golang.org/x/net v0.20.0
 )
 
 require (
@@ -35,7 +36,6 @@ require (
 	github.com/wailsapp/mimetype v1.4.1 // indirect
 	golang.org/x/crypto v0.18.0 // indirect
 	golang.org/x/exp v0.0.0-20230522175609-2e198f4a06a1 // indirect
-	golang.org/x/net v0.20.0 // indirect
 	golang.org/x/sys v0.16.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 )
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gitlab.com/turn1de/acc_client"
+)
+
+// DefaultLayout is used for sessions without a layout of their own
+const DefaultLayout = "default"
+
+// WidgetLayout is the placement and data subscriptions of an overlay widget
+type WidgetLayout struct {
+	Id            string   `json:"id"`
+	Monitor       int      `json:"monitor"`
+	X             int      `json:"x"`
+	Y             int      `json:"y"`
+	Width         int      `json:"width"`
+	Height        int      `json:"height"`
+	Visible       bool     `json:"visible"`
+	Subscriptions []string `json:"subscriptions"`
+}
+
+// Layout is a named arrangement of overlay widgets
+type Layout struct {
+	Name    string         `json:"name"`
+	Widgets []WidgetLayout `json:"widgets"`
+}
+
+// layoutsVersion is the schema version of the layouts file written by this build
+const layoutsVersion = 1
+
+// layoutsFile is the layouts file on disk. Version lets later builds migrate
+// older files instead of failing to parse them.
+type layoutsFile struct {
+	Version int               `json:"version"`
+	Layouts map[string]Layout `json:"layouts"`
+}
+
+// LayoutStore persists overlay layouts keyed by session type
+type LayoutStore struct {
+	mu      sync.RWMutex
+	path    string
+	layouts map[string]Layout
+	active  string
+}
+
+// NewLayoutStore creates a store backed by the file at path
+func NewLayoutStore(path string) *LayoutStore {
+	return &LayoutStore{path: path, layouts: make(map[string]Layout), active: DefaultLayout}
+}
+
+// defaultLayoutPath returns the layouts file in the user config directory
+func defaultLayoutPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tracktic", "layouts.json"), nil
+}
+
+// Load reads the layouts file. A missing file leaves the store empty, older
+// versions are migrated, and a file that cannot be used is moved aside to
+// path.broken.
+func (s *LayoutStore) Load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	layouts, err := decodeLayouts(data)
+	if err != nil {
+		// move the file aside so the next save does not replace the layouts it still holds
+		broken := s.path + ".broken"
+		if renameErr := os.Rename(s.path, broken); renameErr != nil {
+			return fmt.Errorf("load %s: %w (keeping it failed: %v)", s.path, err, renameErr)
+		}
+		return fmt.Errorf("load %s: %w (moved to %s)", s.path, err, broken)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.layouts = layouts
+	return nil
+}
+
+// save writes the layouts file, or does nothing without a path. Callers must hold mu.
+func (s *LayoutStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(layoutsFile{Version: layoutsVersion, Layouts: s.layouts}, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	// write a temporary file and rename it so a crash never leaves a truncated file
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// decodeLayouts parses a layouts file of any known version
+func decodeLayouts(data []byte) (map[string]Layout, error) {
+	var f layoutsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case f.Version == 0:
+		// files written before versioning hold the bare layouts map
+		layouts := make(map[string]Layout)
+		if err := json.Unmarshal(data, &layouts); err != nil {
+			return nil, err
+		}
+		return layouts, nil
+	case f.Version > layoutsVersion:
+		return nil, fmt.Errorf("version %d is newer than the supported version %d", f.Version, layoutsVersion)
+	}
+
+	if f.Layouts == nil {
+		f.Layouts = make(map[string]Layout)
+	}
+	return f.Layouts, nil
+}
+
+// Get returns the layout for key, falling back to the default layout
+func (s *LayoutStore) Get(key string) Layout {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if l, ok := s.layouts[key]; ok {
+		return l
+	}
+	if l, ok := s.layouts[DefaultLayout]; ok {
+		return l
+	}
+	return Layout{Name: DefaultLayout, Widgets: []WidgetLayout{}}
+}
+
+// Set stores the layout for key and persists all layouts
+func (s *LayoutStore) Set(key string, layout Layout) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, had := s.layouts[key]
+	s.layouts[key] = layout
+	if err := s.save(); err != nil {
+		s.restore(key, prev, had)
+		return err
+	}
+	return nil
+}
+
+// Delete removes the layout for key and persists all layouts
+func (s *LayoutStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, had := s.layouts[key]
+	delete(s.layouts, key)
+	if err := s.save(); err != nil {
+		s.restore(key, prev, had)
+		return err
+	}
+	return nil
+}
+
+// restore puts back the layout for key after a failed save. Callers must hold mu.
+func (s *LayoutStore) restore(key string, prev Layout, had bool) {
+	if had {
+		s.layouts[key] = prev
+	} else {
+		delete(s.layouts, key)
+	}
+}
+
+// Activate makes key the active layout and reports whether it changed
+func (s *LayoutStore) Activate(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active == key {
+		return false
+	}
+	s.active = key
+	return true
+}
+
+// Active returns the key of the active layout
+func (s *LayoutStore) Active() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// layoutKey returns the layout key used for a session type
+func layoutKey(sessionType acc_client.SessionType) string {
+	switch sessionType {
+	case acc_client.SessionTypePractice:
+		return "practice"
+	case acc_client.SessionTypeQualifying, acc_client.SessionTypeSuperpole:
+		return "qualifying"
+	case acc_client.SessionTypeRace:
+		return "race"
+	case acc_client.SessionTypeHotlap, acc_client.SessionTypeHotstint, acc_client.SessionTypeHotlapSuperpole:
+		return "hotlap"
+	case acc_client.SessionTypeReplay:
+		return "replay"
+	default:
+		return DefaultLayout
+	}
+}
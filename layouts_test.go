@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLayoutStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tracktic", "layouts.json")
+
+	race := Layout{Name: "race", Widgets: []WidgetLayout{
+		{Id: "standings", Monitor: 1, X: 10, Y: 20, Width: 400, Height: 600, Visible: true, Subscriptions: []string{"standings"}},
+	}}
+	practice := Layout{Name: "practice", Widgets: []WidgetLayout{}}
+
+	s := NewLayoutStore(path)
+	if err := s.Set("race", race); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("practice", practice); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("practice"); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewLayoutStore(path)
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Get("race"); !reflect.DeepEqual(got, race) {
+		t.Errorf("Get(race) = %+v, want %+v", got, race)
+	}
+	if got := loaded.Get("practice"); got.Name != DefaultLayout {
+		t.Errorf("deleted layout was loaded: %+v", got)
+	}
+}
+
+func TestLayoutStoreDefaultFallback(t *testing.T) {
+	s := NewLayoutStore(filepath.Join(t.TempDir(), "layouts.json"))
+	if got := s.Get("race"); got.Name != DefaultLayout || len(got.Widgets) != 0 {
+		t.Errorf("empty store Get(race) = %+v, want empty default", got)
+	}
+
+	def := Layout{Name: "mine", Widgets: []WidgetLayout{{Id: "session"}}}
+	if err := s.Set(DefaultLayout, def); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Get("race"); !reflect.DeepEqual(got, def) {
+		t.Errorf("Get(race) = %+v, want default %+v", got, def)
+	}
+}
+
+func TestLayoutStoreKeepsUnreadableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "layouts.json")
+	truncated := []byte(`{"race": {"name": "race", "widg`)
+	if err := os.WriteFile(path, truncated, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewLayoutStore(path)
+	if err := s.Load(); err == nil {
+		t.Fatal("Load of a truncated file succeeded")
+	}
+	if err := s.Set("qualifying", Layout{Name: "qualifying"}); err != nil {
+		t.Fatal(err)
+	}
+
+	kept, err := os.ReadFile(path + ".broken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(kept) != string(truncated) {
+		t.Errorf("broken file = %q, want %q", kept, truncated)
+	}
+}
+
+func TestLayoutStoreRollsBackFailedSave(t *testing.T) {
+	// a regular file where the config directory should be makes every save fail
+	dir := filepath.Join(t.TempDir(), "tracktic")
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewLayoutStore(filepath.Join(dir, "layouts.json"))
+	if err := s.Set("race", Layout{Name: "race"}); err == nil {
+		t.Fatal("Set succeeded without a writable directory")
+	}
+	if got := s.Get("race"); got.Name != DefaultLayout {
+		t.Errorf("failed Set was applied: %+v", got)
+	}
+}
+
+func TestLayoutStoreWithoutPath(t *testing.T) {
+	s := NewLayoutStore("")
+	if err := s.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("race", Layout{Name: "race"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Get("race"); got.Name != "race" {
+		t.Errorf("Get(race) = %+v, want in-memory layout", got)
+	}
+}
+
+func TestLayoutStoreFileVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "layouts.json")
+	s := NewLayoutStore(path)
+	if err := s.Set("race", Layout{Name: "race"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var f layoutsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	if f.Version != layoutsVersion || f.Layouts["race"].Name != "race" {
+		t.Errorf("file = %s, want version %d with the race layout", data, layoutsVersion)
+	}
+}
+
+func TestLayoutStoreLoadsUnversionedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "layouts.json")
+	if err := os.WriteFile(path, []byte(`{"race": {"name": "race", "widgets": []}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewLayoutStore(path)
+	if err := s.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Get("race"); got.Name != "race" {
+		t.Errorf("Get(race) = %+v, want the unversioned race layout", got)
+	}
+}
+
+func TestLayoutStoreKeepsNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "layouts.json")
+	newer := []byte(`{"version": 99, "layouts": {}}`)
+	if err := os.WriteFile(path, newer, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewLayoutStore(path).Load(); err == nil {
+		t.Fatal("Load of a newer version succeeded")
+	}
+	if kept, err := os.ReadFile(path + ".broken"); err != nil || string(kept) != string(newer) {
+		t.Errorf("newer file was not kept: %q, %v", kept, err)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+// ServerAddress is where the live data server listens for companion apps
+const ServerAddress = "127.0.0.1:8765"
+
+// clientQueueSize is how many messages may wait for a slow client before dropping
+const clientQueueSize = 32
+
+// Message is sent to subscribed clients for every published topic update
+type Message struct {
+	Topic string      `json:"topic"`
+	Data  interface{} `json:"data"`
+}
+
+// subscription is a request from a client to change its topics
+type subscription struct {
+	Subscribe   []string `json:"subscribe"`
+	Unsubscribe []string `json:"unsubscribe"`
+}
+
+// serverClient is a connected WebSocket client and the topics it follows
+type serverClient struct {
+	topics map[string]bool
+	send   chan Message
+}
+
+// LiveServer publishes live timing updates to WebSocket clients by topic
+// and serves the current values over plain HTTP
+type LiveServer struct {
+	mu      sync.RWMutex
+	clients map[*serverClient]bool
+	mux     *http.ServeMux
+	server  *http.Server
+	logf    func(format string, args ...interface{})
+}
+
+// NewLiveServer creates a server listening on addr that reports errors to logf
+func NewLiveServer(addr string, logf func(format string, args ...interface{})) *LiveServer {
+	s := &LiveServer{clients: make(map[*serverClient]bool), logf: logf}
+
+	s.mux = http.NewServeMux()
+	s.mux.Handle("/ws", websocket.Server{Handler: s.handle, Handshake: checkLoopbackOrigin})
+	s.server = &http.Server{Addr: addr, Handler: s.mux}
+	return s
+}
+
+// checkLoopbackOrigin accepts clients without an Origin, such as companion apps
+// and dashboards, the app's own Wails windows, and browser pages served from this
+// machine. Any other web page is rejected so it cannot read the live feed.
+func checkLoopbackOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	config.Origin = origin
+	if origin == nil {
+		return nil
+	}
+
+	host := origin.Hostname()
+	// packaged builds load the frontend from http://wails.localhost on Windows and wails://wails elsewhere
+	if host == "localhost" || host == "wails.localhost" || (origin.Scheme == "wails" && host == "wails") {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("origin %s not allowed", origin)
+}
+
+// HandleGet serves the value returned by get as JSON on GET requests to pattern
+func (s *LiveServer) HandleGet(pattern string, get func() interface{}) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(get()); err != nil {
+			s.logf("live server: encoding %s: %v", pattern, err)
+		}
+	})
+}
+
+// Start listens in the background. It returns once the address is bound.
+func (s *LiveServer) Start() error {
+	ln, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := s.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logf("live server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop closes the listener
+func (s *LiveServer) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// Publish sends data to every client subscribed to topic
+func (s *LiveServer) Publish(topic string, data interface{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	msg := Message{Topic: topic, Data: data}
+	for c := range s.clients {
+		if !c.topics[topic] {
+			continue
+		}
+		select {
+		case c.send <- msg:
+		default:
+			// the client is not keeping up, drop rather than stall the ACC listener
+		}
+	}
+}
+
+// handle serves a single WebSocket connection
+func (s *LiveServer) handle(ws *websocket.Conn) {
+	c := &serverClient{topics: make(map[string]bool), send: make(chan Message, clientQueueSize)}
+
+	s.mu.Lock()
+	s.clients[c] = true
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for msg := range c.send {
+			if err := websocket.JSON.Send(ws, msg); err != nil {
+				ws.Close()
+				return
+			}
+		}
+	}()
+
+	for {
+		var frame []byte
+		if err := websocket.Message.Receive(ws, &frame); err != nil {
+			break
+		}
+
+		var sub subscription
+		if err := json.Unmarshal(frame, &sub); err != nil {
+			// a bad subscription is the client's mistake, tell it and keep the connection
+			select {
+			case c.send <- Message{Topic: "error", Data: "invalid subscription: " + err.Error()}:
+			default:
+			}
+			continue
+		}
+
+		s.mu.Lock()
+		for _, topic := range sub.Subscribe {
+			c.topics[topic] = true
+		}
+		for _, topic := range sub.Unsubscribe {
+			delete(c.topics, topic)
+		}
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	delete(s.clients, c)
+	close(c.send)
+	s.mu.Unlock()
+	<-done
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+func newTestServer(t *testing.T) (*LiveServer, *httptest.Server) {
+	s := NewLiveServer("", t.Logf)
+	ts := httptest.NewServer(s.mux)
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+func TestLiveServerOrigin(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	tests := []struct {
+		origin string
+		want   int
+	}{
+		{"", http.StatusSwitchingProtocols},
+		{"http://localhost:34115", http.StatusSwitchingProtocols},
+		{"http://127.0.0.1", http.StatusSwitchingProtocols},
+		{"http://wails.localhost", http.StatusSwitchingProtocols},
+		{"wails://wails", http.StatusSwitchingProtocols},
+		{"http://wails", http.StatusForbidden},
+		{"wails://evil.example", http.StatusForbidden},
+		{"http://evil.example", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/ws", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Version", "13")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("origin %q: status %d, want %d", tt.origin, resp.StatusCode, tt.want)
+		}
+	}
+}
+
+func TestLiveServerSubscription(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	ws, err := websocket.Dial("ws"+ts.URL[len("http"):]+"/ws", "", "http://localhost/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	ws.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// a malformed subscription is reported without dropping the connection
+	if err := websocket.Message.Send(ws, "{not json"); err != nil {
+		t.Fatal(err)
+	}
+	var msg Message
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Topic != "error" {
+		t.Errorf("got topic %q, want error", msg.Topic)
+	}
+
+	if err := websocket.Message.Send(ws, `{"subscribe": ["standings"]}`); err != nil {
+		t.Fatal(err)
+	}
+	// the subscription is applied asynchronously, publish until it arrives
+	received := make(chan Message, 1)
+	go func() {
+		var m Message
+		if websocket.JSON.Receive(ws, &m) == nil {
+			received <- m
+		}
+	}()
+	deadline := time.After(5 * time.Second)
+	for {
+		s.Publish("session", "ignored")
+		s.Publish("standings", "table")
+		select {
+		case m := <-received:
+			if m.Topic != "standings" || m.Data != "table" {
+				t.Errorf("got %+v, want standings table", m)
+			}
+			return
+		case <-deadline:
+			t.Fatal("no standings message received after subscribing")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestLiveServerPublishDropsForSlowClient(t *testing.T) {
+	s := NewLiveServer("", t.Logf)
+	c := &serverClient{topics: map[string]bool{"standings": true}, send: make(chan Message, clientQueueSize)}
+	s.clients[c] = true
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < clientQueueSize*2; i++ {
+			s.Publish("standings", i)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a client that is not reading")
+	}
+	if len(c.send) != clientQueueSize {
+		t.Errorf("queued %d messages, want %d", len(c.send), clientQueueSize)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"sort"
+	"sync"
+
+	"gitlab.com/turn1de/acc_client"
+)
+
+// Driver is a single driver entry of a car
+type Driver struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	ShortName string `json:"shortName"`
+}
+
+// CarStanding is the live timing row of a single car
+type CarStanding struct {
+	CarId         int      `json:"carId"`
+	RaceNumber    int      `json:"raceNumber"`
+	TeamName      string   `json:"teamName"`
+	CarModel      int      `json:"carModel"`
+	CupCategory   int      `json:"cupCategory"`
+	Drivers       []Driver `json:"drivers"`
+	CurrentDriver int      `json:"currentDriver"`
+	Position      int      `json:"position"`
+	CupPosition   int      `json:"cupPosition"`
+	TrackPosition int      `json:"trackPosition"`
+	Spline        float32  `json:"spline"`
+	Laps          int      `json:"laps"`
+	DeltaMs       int      `json:"deltaMs"`
+	InPit         bool     `json:"inPit"`
+	CurrentLapMs  int      `json:"currentLapMs"`
+	LastLapMs     int      `json:"lastLapMs"`
+	BestLapMs     int      `json:"bestLapMs"`
+	// sector splits in ms, -1 when not set yet
+	CurrentSectorsMs []int `json:"currentSectorsMs"`
+	LastSectorsMs    []int `json:"lastSectorsMs"`
+	BestSectorsMs    []int `json:"bestSectorsMs"`
+}
+
+// WeatherData is the track condition reported with each session update
+type WeatherData struct {
+	AmbientTemp int `json:"ambientTemp"`
+	TrackTemp   int `json:"trackTemp"`
+}
+
+// SessionInfo is the state of the running session
+type SessionInfo struct {
+	SessionIndex     int         `json:"sessionIndex"`
+	TrackName        string      `json:"trackName"`
+	TrackLength      int         `json:"trackLength"`
+	SessionType      int         `json:"sessionType"`
+	Phase            int         `json:"phase"`
+	SessionTimeMs    int         `json:"sessionTimeMs"`
+	SessionEndTimeMs int         `json:"sessionEndTimeMs"`
+	FocusedCarId     int         `json:"focusedCarId"`
+	BestLapMs        int         `json:"bestLapMs"`
+	Weather          WeatherData `json:"weather"`
+}
+
+// Standings collects the ACC broadcast updates into the full field standings
+type Standings struct {
+	mu      sync.RWMutex
+	cars    map[uint16]*CarStanding
+	session SessionInfo
+	target  ClassTarget
+	changed bool
+}
+
+// NewStandings creates an empty standings table
+func NewStandings() *Standings {
+	return &Standings{cars: make(map[uint16]*CarStanding)}
+}
+
+// car returns the row for id, creating it if needed. Callers must hold mu.
+func (s *Standings) car(id uint16) *CarStanding {
+	c, ok := s.cars[id]
+	if !ok {
+		c = &CarStanding{CarId: int(id), LastLapMs: -1, BestLapMs: -1, CurrentLapMs: -1}
+		s.cars[id] = c
+	}
+	return c
+}
+
+// Reset drops all cars and session state
+func (s *Standings) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cars = make(map[uint16]*CarStanding)
+	s.session = SessionInfo{}
+	s.changed = true
+}
+
+// OnEntryListUpdate drops cars that left the server
+func (s *Standings) OnEntryListUpdate(entryList acc_client.EntryList) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keep := make(map[uint16]*CarStanding, len(entryList))
+	for _, id := range entryList {
+		keep[id] = s.car(id)
+	}
+	s.cars = keep
+	s.changed = true
+}
+
+// OnEntryListCarUpdate stores the car and driver details
+func (s *Standings) OnEntryListCarUpdate(entry acc_client.EntryListCar) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.car(entry.Id)
+	c.RaceNumber = int(entry.RaceNumber)
+	c.TeamName = entry.TeamName
+	c.CarModel = int(entry.Model)
+	c.CupCategory = int(entry.CupCategory)
+	c.CurrentDriver = int(entry.CurrentDriverId)
+	c.Drivers = make([]Driver, len(entry.Drivers))
+	for i, d := range entry.Drivers {
+		c.Drivers[i] = Driver{FirstName: d.FirstName, LastName: d.LastName, ShortName: d.ShortName}
+	}
+	s.changed = true
+}
+
+// OnRealtimeCarUpdate stores position, lap and pit state of a car
+func (s *Standings) OnRealtimeCarUpdate(update acc_client.RealtimeCarUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.car(update.Id)
+	c.CurrentDriver = int(update.DriverId)
+	c.Position = int(update.Position)
+	c.CupPosition = int(update.CupPosition)
+	c.TrackPosition = int(update.TrackPosition)
+	c.Spline = update.SplinePosition
+	c.Laps = int(update.Laps)
+	c.DeltaMs = int(update.Delta)
+	c.InPit = update.CarLocation == acc_client.CarLocationPitlane ||
+		update.CarLocation == acc_client.CarLocationPitEntry ||
+		update.CarLocation == acc_client.CarLocationPitExit
+	c.CurrentLapMs = int(update.CurrentLap.LapTimeMs)
+	c.LastLapMs = int(update.LastLap.LapTimeMs)
+	c.BestLapMs = int(update.BestSessionLap.LapTimeMs)
+	c.CurrentSectorsMs = splits(update.CurrentLap)
+	c.LastSectorsMs = splits(update.LastLap)
+	if update.LastLap.IsValidForBest {
+		c.BestSectorsMs = bestSplits(c.BestSectorsMs, c.LastSectorsMs)
+	}
+	s.changed = true
+}
+
+// splits converts the sector splits of a lap to ms
+func splits(lap acc_client.Lap) []int {
+	s := make([]int, len(lap.Splits))
+	for i, split := range lap.Splits {
+		s[i] = int(split)
+	}
+	return s
+}
+
+// bestSplits returns the per sector minimum of best and last
+func bestSplits(best, last []int) []int {
+	merged := make([]int, len(last))
+	for i, split := range last {
+		merged[i] = split
+		if i < len(best) && best[i] > 0 && (split <= 0 || best[i] < split) {
+			merged[i] = best[i]
+		}
+	}
+	if len(best) > len(last) {
+		merged = append(merged, best[len(last):]...)
+	}
+	return merged
+}
+
+// OnRealtimeUpdate stores the session state
+func (s *Standings) OnRealtimeUpdate(update acc_client.RealtimeUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// sector bests are collected here while ACC resets its best laps per session
+	if int(update.SessionIndex) != s.session.SessionIndex {
+		for _, c := range s.cars {
+			c.BestSectorsMs = nil
+		}
+	}
+	s.session.SessionIndex = int(update.SessionIndex)
+	s.session.SessionType = int(update.SessionType)
+	s.session.Phase = int(update.Phase)
+	s.session.SessionTimeMs = int(update.SessionTime)
+	s.session.SessionEndTimeMs = int(update.SessionEndTime)
+	s.session.FocusedCarId = int(update.FocusedCarIndex)
+	s.session.BestLapMs = int(update.BestSessionLap.LapTimeMs)
+	// Clouds, RainLevel and Wetness are truncated to whole numbers by acc_client, which leaves only 0 or 1
+	s.session.Weather = WeatherData{
+		AmbientTemp: int(update.AmbientTemp),
+		TrackTemp:   int(update.TrackTemp),
+	}
+	s.changed = true
+}
+
+// OnTrackUpdate stores the track details
+func (s *Standings) OnTrackUpdate(track acc_client.TrackData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.session.TrackName = track.Name
+	s.session.TrackLength = int(track.Length)
+	s.changed = true
+}
+
+// TakeChanged reports whether anything changed since the last call and clears the mark
+func (s *Standings) TakeChanged() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := s.changed
+	s.changed = false
+	return changed
+}
+
+// Cars returns a copy of all cars ordered by position
+func (s *Standings) Cars() []CarStanding {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cars := make([]CarStanding, 0, len(s.cars))
+	for _, c := range s.cars {
+		cars = append(cars, *c)
+	}
+	sort.Slice(cars, func(i, j int) bool {
+		// cars without an official position yet go last
+		if (cars[i].Position == 0) != (cars[j].Position == 0) {
+			return cars[j].Position == 0
+		}
+		if cars[i].Position != cars[j].Position {
+			return cars[i].Position < cars[j].Position
+		}
+		return cars[i].CarId < cars[j].CarId
+	})
+	return cars
+}
+
+// Session returns a copy of the session state
+func (s *Standings) Session() SessionInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.session
+}
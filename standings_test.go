@@ -0,0 +1,123 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"gitlab.com/turn1de/acc_client"
+)
+
+func TestBestSplits(t *testing.T) {
+	tests := []struct {
+		name string
+		best []int
+		last []int
+		want []int
+	}{
+		{"no best yet", nil, []int{30000, 40000, 35000}, []int{30000, 40000, 35000}},
+		{"keeps faster best", []int{29000, 39000, 34000}, []int{30000, 40000, 35000}, []int{29000, 39000, 34000}},
+		{"takes faster last", []int{30000, 40000, 35000}, []int{29000, 41000, 34000}, []int{29000, 40000, 34000}},
+		{"missing last split keeps best", []int{30000, 40000, 35000}, []int{29000, -1, -1}, []int{29000, 40000, 35000}},
+		{"missing best split takes last", []int{30000, -1, -1}, []int{31000, 40000, 35000}, []int{30000, 40000, 35000}},
+		{"both missing", []int{-1}, []int{-1}, []int{-1}},
+		{"shorter last keeps best tail", []int{30000, 40000, 35000}, []int{29000}, []int{29000, 40000, 35000}},
+		{"longer last adds sectors", []int{30000}, []int{31000, 40000, 35000}, []int{30000, 40000, 35000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bestSplits(tt.best, tt.last); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bestSplits(%v, %v) = %v, want %v", tt.best, tt.last, got, tt.want)
+			}
+		})
+	}
+}
+
+// carUpdate returns a car update whose last lap has the given splits
+func carUpdate(id uint16, splits []int32, validForBest bool) acc_client.RealtimeCarUpdate {
+	return acc_client.RealtimeCarUpdate{
+		Id:      id,
+		LastLap: acc_client.Lap{Splits: splits, IsValidForBest: validForBest},
+	}
+}
+
+func TestStandingsSectorBestsOnlyFromLapsValidForBest(t *testing.T) {
+	s := NewStandings()
+	s.OnRealtimeCarUpdate(carUpdate(1, []int32{30000, 40000, 35000}, true))
+	s.OnRealtimeCarUpdate(carUpdate(1, []int32{29000, 39000, 34000}, false))
+
+	car := s.Cars()[0]
+	if want := []int{30000, 40000, 35000}; !reflect.DeepEqual(car.BestSectorsMs, want) {
+		t.Errorf("BestSectorsMs = %v, want %v", car.BestSectorsMs, want)
+	}
+	if want := []int{29000, 39000, 34000}; !reflect.DeepEqual(car.LastSectorsMs, want) {
+		t.Errorf("LastSectorsMs = %v, want %v", car.LastSectorsMs, want)
+	}
+}
+
+func TestStandingsSessionChangeClearsSectorBests(t *testing.T) {
+	s := NewStandings()
+	s.OnRealtimeUpdate(acc_client.RealtimeUpdate{SessionIndex: 0})
+	s.OnRealtimeCarUpdate(carUpdate(1, []int32{30000, 40000, 35000}, true))
+	s.OnRealtimeCarUpdate(carUpdate(2, []int32{31000, 41000, 36000}, true))
+
+	// further updates of the same session keep the bests
+	s.OnRealtimeUpdate(acc_client.RealtimeUpdate{SessionIndex: 0})
+	for _, car := range s.Cars() {
+		if len(car.BestSectorsMs) != 3 {
+			t.Errorf("car %d lost its sector bests within a session: %v", car.CarId, car.BestSectorsMs)
+		}
+	}
+
+	s.OnRealtimeUpdate(acc_client.RealtimeUpdate{SessionIndex: 1})
+	for _, car := range s.Cars() {
+		if car.BestSectorsMs != nil {
+			t.Errorf("car %d kept sector bests %v after the session changed", car.CarId, car.BestSectorsMs)
+		}
+	}
+	if got := s.Session().SessionIndex; got != 1 {
+		t.Errorf("SessionIndex = %d, want 1", got)
+	}
+}
+
+func TestStandingsCarsOrder(t *testing.T) {
+	s := NewStandings()
+	for _, u := range []acc_client.RealtimeCarUpdate{
+		{Id: 7, Position: 0},
+		{Id: 3, Position: 2},
+		{Id: 5, Position: 1},
+		{Id: 2, Position: 0},
+		{Id: 9, Position: 2},
+	} {
+		s.OnRealtimeCarUpdate(u)
+	}
+
+	var got []int
+	for _, car := range s.Cars() {
+		got = append(got, car.CarId)
+	}
+	// positioned cars first, ties and unpositioned cars ordered by CarId
+	if want := []int{5, 3, 9, 2, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Cars() order = %v, want %v", got, want)
+	}
+}
+
+func TestStandingsEntryListDropsCarsThatLeft(t *testing.T) {
+	s := NewStandings()
+	s.OnEntryListCarUpdate(acc_client.EntryListCar{Id: 1, RaceNumber: 11, TeamName: "stays"})
+	s.OnEntryListCarUpdate(acc_client.EntryListCar{Id: 2, RaceNumber: 22, TeamName: "leaves"})
+	s.OnRealtimeCarUpdate(acc_client.RealtimeCarUpdate{Id: 1, Position: 1, Laps: 4})
+
+	s.OnEntryListUpdate(acc_client.EntryList{1, 3})
+
+	cars := s.Cars()
+	if len(cars) != 2 {
+		t.Fatalf("got %d cars, want 2: %+v", len(cars), cars)
+	}
+	if c := cars[0]; c.CarId != 1 || c.RaceNumber != 11 || c.TeamName != "stays" || c.Laps != 4 {
+		t.Errorf("existing row was not kept: %+v", c)
+	}
+	if c := cars[1]; c.CarId != 3 || c.LastLapMs != -1 || c.BestLapMs != -1 {
+		t.Errorf("new row = %+v, want empty row for car 3", c)
+	}
+}